@@ -0,0 +1,78 @@
+package openapi
+
+// The types below are a minimal, dependency-free subset of the OpenAPI 3.0 /
+// JSON Schema object model — just enough of the spec's JSON shape for this
+// package's Export/JSONSchema output to be valid input to Swagger UI and
+// standard code generators, without vendoring a third-party OpenAPI library.
+
+// Document is the root OpenAPI 3.0 document produced by Export.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+// Info is the OpenAPI document's Info Object.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Components holds the document's reusable Components Object, keyed by
+// schema name (a Schema's CodeName) as referenced via Schema.Ref.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// PathItem is the OpenAPI Path Item Object for a single collection or
+// resource path.
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Put    *Operation `json:"put,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+}
+
+// Operation is the OpenAPI Operation Object.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is the OpenAPI Parameter Object.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+// Response is the OpenAPI Response Object.
+type Response struct {
+	Description string `json:"description"`
+}
+
+// Schema is a JSON Schema / OpenAPI 3.0 Schema Object, covering the subset
+// of keywords this package emits from a types.Schema's ResourceFields:
+// primitive types, array/map nesting, $ref to another component schema,
+// enum options, nullable, and the length/range/pattern constraints carried
+// on types.Field.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	MinLength            *int64             `json:"minLength,omitempty"`
+	MaxLength            *int64             `json:"maxLength,omitempty"`
+	Minimum              *int64             `json:"minimum,omitempty"`
+	Maximum              *int64             `json:"maximum,omitempty"`
+}
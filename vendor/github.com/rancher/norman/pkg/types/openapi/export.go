@@ -0,0 +1,233 @@
+// Package openapi renders the schemas registered on a types.Schemas into an
+// OpenAPI 3.0 document, and individual schemas into standalone JSON Schema
+// definitions, so that tooling outside Rancher's dynamic API (Swagger UI,
+// client generators) can consume the same schema registry.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rancher/norman/pkg/types"
+)
+
+// Options controls the document metadata written into the exported spec.
+type Options struct {
+	Title       string
+	Description string
+	Version     string
+	BasePath    string
+}
+
+func (o Options) withDefaults() Options {
+	if o.Title == "" {
+		o.Title = "API"
+	}
+	if o.Version == "" {
+		o.Version = "v1"
+	}
+	if o.BasePath == "" {
+		o.BasePath = "/v1"
+	}
+	return o
+}
+
+// Export renders every schema registered on s into an OpenAPI 3.0 document,
+// including component schemas, $ref-linked field types, and path items for
+// each schema's collection and resource methods.
+func Export(s *types.Schemas, opts Options) (*Document, error) {
+	opts = opts.withDefaults()
+
+	doc := &Document{
+		OpenAPI: "3.0.0",
+		Info: Info{
+			Title:       opts.Title,
+			Description: opts.Description,
+			Version:     opts.Version,
+		},
+		Paths: map[string]*PathItem{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+		},
+	}
+
+	for _, schema := range s.Schemas() {
+		componentSchema, err := toOpenAPISchema(s, schema)
+		if err != nil {
+			return nil, fmt.Errorf("schema %s: %w", schema.ID, err)
+		}
+		doc.Components.Schemas[schema.CodeName] = componentSchema
+
+		for path, item := range pathItems(schema, opts.BasePath) {
+			doc.Paths[path] = item
+		}
+	}
+
+	return doc, nil
+}
+
+// JSONSchema renders a single registered schema, keyed by schemaID, as a
+// standalone JSON Schema document.
+func JSONSchema(s *types.Schemas, schemaID string) ([]byte, error) {
+	schema := s.Schema(schemaID)
+	if schema == nil {
+		return nil, fmt.Errorf("no such schema: %s", schemaID)
+	}
+
+	jsonSchema, err := toOpenAPISchema(s, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(jsonSchema, "", "  ")
+}
+
+func toOpenAPISchema(s *types.Schemas, schema *types.Schema) (*Schema, error) {
+	result := &Schema{
+		Type:       "object",
+		Properties: map[string]*Schema{},
+	}
+
+	var required []string
+	fieldNames := make([]string, 0, len(schema.ResourceFields))
+	for name := range schema.ResourceFields {
+		fieldNames = append(fieldNames, name)
+	}
+	sort.Strings(fieldNames)
+
+	for _, name := range fieldNames {
+		field := schema.ResourceFields[name]
+		fieldSchema, err := toFieldSchema(s, field)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		result.Properties[name] = fieldSchema
+		if field.Required {
+			required = append(required, name)
+		}
+	}
+
+	result.Required = required
+	return result, nil
+}
+
+func toFieldSchema(s *types.Schemas, field types.Field) (*Schema, error) {
+	fieldType := field.Type
+
+	if strings.HasPrefix(fieldType, "array[") {
+		elemSchema, err := toFieldSchema(s, types.Field{Type: strings.TrimSuffix(strings.TrimPrefix(fieldType, "array["), "]")})
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: elemSchema}, nil
+	}
+
+	if strings.HasPrefix(fieldType, "map[") {
+		valueSchema, err := toFieldSchema(s, types.Field{Type: strings.TrimSuffix(strings.TrimPrefix(fieldType, "map["), "]")})
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: valueSchema}, nil
+	}
+
+	result := &Schema{}
+	switch fieldType {
+	case "string", "enum", "date":
+		result.Type = "string"
+	case "int":
+		result.Type = "integer"
+	case "float":
+		result.Type = "number"
+	case "boolean":
+		result.Type = "boolean"
+	default:
+		// Either the ID of another registered schema (a nested struct
+		// field) or a reference[<schemaID>] wrapper around one. Resolve
+		// to that schema's CodeName, since that's the key Export uses
+		// under Components.Schemas, not the lowercase schema ID.
+		referencedID := strings.TrimSuffix(strings.TrimPrefix(fieldType, "reference["), "]")
+		referenced := s.Schema(referencedID)
+		if referenced == nil {
+			return nil, fmt.Errorf("field type %q references unknown schema %q", fieldType, referencedID)
+		}
+		result.Ref = "#/components/schemas/" + referenced.CodeName
+		return result, nil
+	}
+
+	result.Enum = field.Options
+	result.Nullable = field.Nullable
+	result.Pattern = field.Pattern
+	result.MinLength = field.MinLength
+	result.MaxLength = field.MaxLength
+	result.Minimum = field.Min
+	result.Maximum = field.Max
+
+	return result, nil
+}
+
+func pathItems(schema *types.Schema, basePath string) map[string]*PathItem {
+	items := map[string]*PathItem{}
+
+	collectionPath := fmt.Sprintf("%s/%s", basePath, schema.PluralName)
+	collectionItem := &PathItem{}
+	if hasMethod(schema.CollectionMethods, "GET") {
+		collectionItem.Get = operation(schema, "list"+schema.CodeNamePlural, false)
+	}
+	if hasMethod(schema.CollectionMethods, "POST") {
+		collectionItem.Post = operation(schema, "create"+schema.CodeName, false)
+	}
+	if collectionItem.Get != nil || collectionItem.Post != nil {
+		items[collectionPath] = collectionItem
+	}
+
+	resourcePath := fmt.Sprintf("%s/%s/{id}", basePath, schema.PluralName)
+	resourceItem := &PathItem{}
+	if hasMethod(schema.ResourceMethods, "GET") {
+		resourceItem.Get = operation(schema, "get"+schema.CodeName, true)
+	}
+	if hasMethod(schema.ResourceMethods, "PUT") {
+		resourceItem.Put = operation(schema, "update"+schema.CodeName, true)
+	}
+	if hasMethod(schema.ResourceMethods, "DELETE") {
+		resourceItem.Delete = operation(schema, "delete"+schema.CodeName, true)
+	}
+	if resourceItem.Get != nil || resourceItem.Put != nil || resourceItem.Delete != nil {
+		items[resourcePath] = resourceItem
+	}
+
+	return items
+}
+
+func operation(schema *types.Schema, operationID string, withIDParam bool) *Operation {
+	op := &Operation{
+		OperationID: operationID,
+		Tags:        []string{schema.CodeNamePlural},
+		Responses: map[string]*Response{
+			"200": {Description: "OK"},
+		},
+	}
+
+	if withIDParam {
+		op.Parameters = []Parameter{
+			{
+				Name:     "id",
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			},
+		}
+	}
+
+	return op
+}
+
+func hasMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,188 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rancher/norman/pkg/types"
+)
+
+func newTestSchemas(t *testing.T) *types.Schemas {
+	t.Helper()
+	s := types.EmptySchemas()
+
+	pattern := "^[a-z0-9-]+$"
+	minLength := int64(1)
+
+	err := s.AddSchema(types.Schema{
+		ID:                "widget",
+		CodeName:          "Widget",
+		CodeNamePlural:    "Widgets",
+		PluralName:        "widgets",
+		CollectionMethods: []string{"GET", "POST"},
+		ResourceMethods:   []string{"GET", "PUT", "DELETE"},
+		ResourceFields: map[string]types.Field{
+			"name": {
+				Type:      "string",
+				Required:  true,
+				Pattern:   pattern,
+				MinLength: &minLength,
+			},
+			"tags": {
+				Type: "array[string]",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("AddSchema: %v", err)
+	}
+	return s
+}
+
+func TestExportProducesPathsAndComponentSchema(t *testing.T) {
+	s := newTestSchemas(t)
+
+	doc, err := Export(s, Options{})
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	if _, ok := doc.Paths["/v1/widgets"]; !ok {
+		t.Fatalf("expected collection path /v1/widgets, got %v", doc.Paths)
+	}
+	if _, ok := doc.Paths["/v1/widgets/{id}"]; !ok {
+		t.Fatalf("expected resource path /v1/widgets/{id}, got %v", doc.Paths)
+	}
+
+	widget, ok := doc.Components.Schemas["Widget"]
+	if !ok {
+		t.Fatalf("expected component schema Widget, got %v", doc.Components.Schemas)
+	}
+	if len(widget.Required) != 1 || widget.Required[0] != "name" {
+		t.Fatalf("expected required=[name], got %v", widget.Required)
+	}
+}
+
+func TestExportRoundTripsPattern(t *testing.T) {
+	s := newTestSchemas(t)
+
+	doc, err := Export(s, Options{})
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	name := doc.Components.Schemas["Widget"].Properties["name"]
+	if name.Pattern != "^[a-z0-9-]+$" {
+		t.Fatalf("expected name.Pattern to round-trip, got %q", name.Pattern)
+	}
+}
+
+func newTestSchemasWithNestedRef(t *testing.T) *types.Schemas {
+	t.Helper()
+	s := types.EmptySchemas()
+
+	if err := s.AddSchema(types.Schema{
+		ID:             "container",
+		CodeName:       "Container",
+		CodeNamePlural: "Containers",
+		PluralName:     "containers",
+		ResourceFields: map[string]types.Field{
+			"image": {Type: "string"},
+		},
+	}); err != nil {
+		t.Fatalf("AddSchema(container): %v", err)
+	}
+
+	if err := s.AddSchema(types.Schema{
+		ID:             "pod",
+		CodeName:       "Pod",
+		CodeNamePlural: "Pods",
+		PluralName:     "pods",
+		ResourceFields: map[string]types.Field{
+			"container":  {Type: "container"},
+			"owner":      {Type: "reference[pod]"},
+			"containers": {Type: "array[container]"},
+		},
+	}); err != nil {
+		t.Fatalf("AddSchema(pod): %v", err)
+	}
+
+	return s
+}
+
+func TestExportResolvesNestedStructRefToCodeName(t *testing.T) {
+	s := newTestSchemasWithNestedRef(t)
+
+	doc, err := Export(s, Options{})
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	pod := doc.Components.Schemas["Pod"]
+	if ref := pod.Properties["container"].Ref; ref != "#/components/schemas/Container" {
+		t.Fatalf("expected container field to $ref Container by CodeName, got %q", ref)
+	}
+}
+
+func TestExportResolvesReferenceWrapperToCodeName(t *testing.T) {
+	s := newTestSchemasWithNestedRef(t)
+
+	doc, err := Export(s, Options{})
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	pod := doc.Components.Schemas["Pod"]
+	if ref := pod.Properties["owner"].Ref; ref != "#/components/schemas/Pod" {
+		t.Fatalf("expected owner field to strip reference[...] and $ref Pod by CodeName, got %q", ref)
+	}
+}
+
+func TestExportResolvesArrayOfNestedStructRef(t *testing.T) {
+	s := newTestSchemasWithNestedRef(t)
+
+	doc, err := Export(s, Options{})
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	pod := doc.Components.Schemas["Pod"]
+	items := pod.Properties["containers"].Items
+	if items == nil || items.Ref != "#/components/schemas/Container" {
+		t.Fatalf("expected containers[] items to $ref Container by CodeName, got %+v", items)
+	}
+}
+
+func TestExportUnknownReferencedSchemaErrors(t *testing.T) {
+	s := types.EmptySchemas()
+	s.MustAddSchema(types.Schema{
+		ID:       "orphan",
+		CodeName: "Orphan",
+		ResourceFields: map[string]types.Field{
+			"parent": {Type: "missing"},
+		},
+	})
+
+	if _, err := Export(s, Options{}); err == nil {
+		t.Fatalf("expected Export to error on a field referencing an unregistered schema")
+	}
+}
+
+func TestJSONSchemaMarshalsArrayField(t *testing.T) {
+	s := newTestSchemas(t)
+
+	raw, err := JSONSchema(s, "widget")
+	if err != nil {
+		t.Fatalf("JSONSchema: %v", err)
+	}
+
+	var decoded Schema
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	tags := decoded.Properties["tags"]
+	if tags.Type != "array" || tags.Items == nil || tags.Items.Type != "string" {
+		t.Fatalf("expected tags to be array[string], got %+v", tags)
+	}
+}
@@ -0,0 +1,353 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/rancher/norman/pkg/types/convert"
+)
+
+const (
+	tagNorman = "norman"
+	tagRio    = "rio"
+)
+
+var fieldBlacklist = map[string]bool{
+	"links":   true,
+	"actions": true,
+}
+
+// TypeName registers an explicit schema ID for obj, overriding the ID that
+// would otherwise be derived from the struct's Go name. It must be called
+// before the type is first imported.
+func (s *Schemas) TypeName(name string, obj interface{}) *Schemas {
+	s.Lock()
+	defer s.Unlock()
+	s.typeNames[reflect.TypeOf(obj)] = name
+	return s
+}
+
+// AddMapperForType registers mappers to run for the schema derived from
+// obj's type, in addition to any mappers assigned via AddMapper.
+func (s *Schemas) AddMapperForType(obj interface{}, mappers ...Mapper) *Schemas {
+	s.Lock()
+	schemaID := s.typeName(reflect.TypeOf(obj))
+	s.Unlock()
+
+	for _, mapper := range mappers {
+		s.AddMapper(schemaID, mapper)
+	}
+	return s
+}
+
+// Import walks obj's type with reflection and registers a Schema for it,
+// along with any nested struct types it references. It is the reflection
+// equivalent of hand-building a Schema and calling AddSchema.
+func (s *Schemas) Import(obj interface{}, externalOverrides ...interface{}) (*Schema, error) {
+	t := reflect.TypeOf(obj)
+	if t.Kind() == reflect.Ptr {
+		return nil, fmt.Errorf("obj cannot be a pointer: %v", t)
+	}
+
+	overrides, err := s.fieldOverrides(externalOverrides...)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.importType(t, overrides)
+}
+
+// MustImport is like Import but panics on error.
+func (s *Schemas) MustImport(obj interface{}, externalOverrides ...interface{}) *Schemas {
+	if _, err := s.Import(obj, externalOverrides...); err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// MustImportAndCustomize imports obj like MustImport, then invokes f with
+// the resulting Schema so callers can tweak it (add mappers, handlers,
+// etc.) inline at the call site.
+func (s *Schemas) MustImportAndCustomize(obj interface{}, f func(*Schema), externalOverrides ...interface{}) *Schemas {
+	schema, err := s.Import(obj, externalOverrides...)
+	if err != nil {
+		panic(err)
+	}
+	f(schema)
+	return s
+}
+
+// SchemaFor returns the schema previously imported for t, or nil if t has
+// not been imported.
+func (s *Schemas) SchemaFor(t reflect.Type) *Schema {
+	s.Lock()
+	defer s.Unlock()
+	return s.schemasByID[s.typeName(t)]
+}
+
+func (s *Schemas) fieldOverrides(externalOverrides ...interface{}) (map[string]reflect.StructField, error) {
+	fields := map[string]reflect.StructField{}
+	for _, override := range externalOverrides {
+		t := reflect.TypeOf(override)
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("override must be a struct: %v", t)
+		}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			fields[fieldName(f)] = f
+		}
+	}
+	return fields, nil
+}
+
+func (s *Schemas) typeName(t reflect.Type) string {
+	if name, ok := s.typeNames[t]; ok {
+		return name
+	}
+	return convert.LowerTitle(t.Name())
+}
+
+func (s *Schemas) importType(t reflect.Type, overrides map[string]reflect.StructField) (*Schema, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	s.Lock()
+	if existing, ok := s.processingTypes[t]; ok {
+		// t is already being built further up the call stack: this is a
+		// cycle (a struct referencing itself, directly or through another
+		// type), not a previously-completed import. Hand back the
+		// in-progress stub so the caller can read its ID; its fields are
+		// filled in and it is registered once the outer call returns.
+		s.Unlock()
+		return existing, nil
+	}
+
+	schema := &Schema{
+		ID:             s.typeName(t),
+		ResourceFields: map[string]Field{},
+	}
+	s.processingTypes[t] = schema
+	s.Unlock()
+
+	// processingTypes only guards against cycles while this build is in
+	// flight. Clear it once we're done (success or failure) so a later,
+	// unrelated call to Import/MustImportAndCustomize for the same type
+	// re-reads fields and re-registers through AddSchema instead of
+	// silently returning this stale, pre-AddSchema pointer.
+	defer func() {
+		s.Lock()
+		delete(s.processingTypes, t)
+		s.Unlock()
+	}()
+
+	if err := s.readFields(schema, t, overrides); err != nil {
+		return nil, err
+	}
+
+	if err := s.AddSchema(*schema); err != nil {
+		return nil, err
+	}
+
+	return s.Schema(schema.ID), nil
+}
+
+func (s *Schemas) readFields(schema *Schema, t reflect.Type, overrides map[string]reflect.StructField) error {
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		if structField.PkgPath != "" {
+			// unexported
+			continue
+		}
+
+		if structField.Anonymous && !hasTagOption(structField, tagNorman, "noflatten") {
+			embeddedType := structField.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			if embeddedType.Kind() == reflect.Struct {
+				if err := s.readFields(schema, embeddedType, overrides); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		name := fieldName(structField)
+		if name == "-" || fieldBlacklist[name] {
+			continue
+		}
+
+		if override, ok := overrides[name]; ok {
+			structField = override
+		}
+
+		field, err := s.buildField(structField)
+		if err != nil {
+			return fmt.Errorf("field %s.%s: %w", t.Name(), structField.Name, err)
+		}
+
+		schema.ResourceFields[name] = field
+	}
+
+	return nil
+}
+
+func (s *Schemas) buildField(structField reflect.StructField) (Field, error) {
+	field := Field{
+		Create: true,
+		Update: true,
+	}
+
+	fieldType, nullable, err := s.fieldType(structField.Type)
+	if err != nil {
+		return field, err
+	}
+	field.Type = fieldType
+	field.Nullable = nullable
+
+	if err := applyTag(&field, structField.Tag.Get(tagNorman)); err != nil {
+		return field, err
+	}
+	if err := applyTag(&field, structField.Tag.Get(tagRio)); err != nil {
+		return field, err
+	}
+
+	return field, nil
+}
+
+func (s *Schemas) fieldType(t reflect.Type) (string, bool, error) {
+	nullable := false
+	if t.Kind() == reflect.Ptr {
+		nullable = true
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string", nullable, nil
+	case reflect.Bool:
+		return "boolean", nullable, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "int", nullable, nil
+	case reflect.Float32, reflect.Float64:
+		return "float", nullable, nil
+	case reflect.Slice, reflect.Array:
+		elemType, _, err := s.fieldType(t.Elem())
+		if err != nil {
+			return "", false, err
+		}
+		return "array[" + elemType + "]", true, nil
+	case reflect.Map:
+		valueType, _, err := s.fieldType(t.Elem())
+		if err != nil {
+			return "", false, err
+		}
+		return "map[" + valueType + "]", true, nil
+	case reflect.Struct:
+		if t.String() == "time.Time" {
+			return "date", nullable, nil
+		}
+		nested, err := s.importType(t, nil)
+		if err != nil {
+			return "", false, err
+		}
+		return nested.ID, true, nil
+	default:
+		return "", false, fmt.Errorf("unsupported field kind: %v", t.Kind())
+	}
+}
+
+func fieldName(f reflect.StructField) string {
+	jsonTag := f.Tag.Get("json")
+	if jsonTag == "" {
+		return convert.LowerTitle(f.Name)
+	}
+
+	name := strings.Split(jsonTag, ",")[0]
+	if name == "" {
+		return convert.LowerTitle(f.Name)
+	}
+	return name
+}
+
+func hasTagOption(f reflect.StructField, tagName, option string) bool {
+	for _, part := range strings.Split(f.Tag.Get(tagName), ",") {
+		if part == option {
+			return true
+		}
+	}
+	return false
+}
+
+func applyTag(field *Field, tag string) error {
+	if tag == "" {
+		return nil
+	}
+
+	for _, part := range strings.Split(tag, ",") {
+		key, value := part, ""
+		if idx := strings.Index(part, "="); idx >= 0 {
+			key, value = part[:idx], part[idx+1:]
+		}
+
+		switch key {
+		case "type":
+			field.Type = value
+		case "required":
+			field.Required = true
+		case "nullable":
+			field.Nullable = true
+		case "notnullable":
+			field.Nullable = false
+		case "create":
+			field.Create = true
+		case "nocreate":
+			field.Create = false
+		case "update":
+			field.Update = true
+		case "noupdate":
+			field.Update = false
+		case "default":
+			field.Default = value
+		case "options":
+			field.Options = strings.Split(value, "|")
+		case "minLength":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return err
+			}
+			field.MinLength = &n
+		case "maxLength":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return err
+			}
+			field.MaxLength = &n
+		case "min":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return err
+			}
+			field.Min = &n
+		case "max":
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return err
+			}
+			field.Max = &n
+		case "pattern":
+			field.Pattern = value
+		case "validate":
+			field.ValidateRule = value
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package types
+
+import (
+	"sync"
+	"testing"
+)
+
+type Address struct {
+	Street string `json:"street"`
+	City   string `json:"city" norman:"required"`
+}
+
+type Person struct {
+	Address
+	Name    string   `json:"name" norman:"required,minLength=1,maxLength=100"`
+	Age     int      `json:"age" norman:"min=0,max=150"`
+	Tags    []string `json:"tags"`
+	Roles   map[string]string
+	Links   string `json:"links"`
+	ignored string
+}
+
+func TestImportFlattensEmbeddedStructs(t *testing.T) {
+	s := EmptySchemas()
+	schema, err := s.Import(Person{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	if _, ok := schema.ResourceFields["street"]; !ok {
+		t.Fatalf("expected embedded Address fields to be flattened onto Person, got %+v", schema.ResourceFields)
+	}
+	if _, ok := schema.ResourceFields["links"]; ok {
+		t.Fatalf("expected blacklisted field %q to be skipped", "links")
+	}
+}
+
+func TestImportAppliesNormanTags(t *testing.T) {
+	s := EmptySchemas()
+	schema, err := s.Import(Person{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	name := schema.ResourceFields["name"]
+	if !name.Required {
+		t.Fatalf("expected name to be required")
+	}
+	if name.MinLength == nil || *name.MinLength != 1 {
+		t.Fatalf("expected name minLength 1, got %+v", name.MinLength)
+	}
+	if name.MaxLength == nil || *name.MaxLength != 100 {
+		t.Fatalf("expected name maxLength 100, got %+v", name.MaxLength)
+	}
+
+	age := schema.ResourceFields["age"]
+	if age.Min == nil || *age.Min != 0 || age.Max == nil || *age.Max != 150 {
+		t.Fatalf("expected age min/max 0/150, got %+v/%+v", age.Min, age.Max)
+	}
+}
+
+func TestImportDerivesArrayAndMapTypes(t *testing.T) {
+	s := EmptySchemas()
+	schema, err := s.Import(Person{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	if schema.ResourceFields["tags"].Type != "array[string]" {
+		t.Fatalf("expected tags type array[string], got %s", schema.ResourceFields["tags"].Type)
+	}
+	if schema.ResourceFields["roles"].Type != "map[string]" {
+		t.Fatalf("expected roles type map[string], got %s", schema.ResourceFields["roles"].Type)
+	}
+}
+
+func TestTypeNameOverride(t *testing.T) {
+	s := EmptySchemas()
+	s.TypeName("widget", Person{})
+
+	schema, err := s.Import(Person{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if schema.ID != "widget" {
+		t.Fatalf("expected overridden schema ID widget, got %s", schema.ID)
+	}
+}
+
+type Widget struct {
+	Name string `json:"name"`
+}
+
+type WidgetNameOverride struct {
+	Name string `json:"name" norman:"required"`
+}
+
+func TestImportTwiceReappliesOverrides(t *testing.T) {
+	s := EmptySchemas()
+
+	first, err := s.Import(Widget{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if first.ResourceFields["name"].Required {
+		t.Fatalf("expected name not required on first import")
+	}
+
+	second, err := s.Import(Widget{}, WidgetNameOverride{})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if !second.ResourceFields["name"].Required {
+		t.Fatalf("expected second import's override to make name required")
+	}
+
+	if got := s.Schema("widget").ResourceFields["name"]; !got.Required {
+		t.Fatalf("expected the registered widget schema to reflect the second import, got %+v", got)
+	}
+}
+
+type Container struct {
+	Image string `json:"image"`
+}
+
+type PodA struct {
+	Name      string    `json:"name"`
+	Container Container `json:"container"`
+}
+
+func TestMustImportAndCustomizeOnSharedNestedType(t *testing.T) {
+	s := EmptySchemas()
+
+	// Registers "container" as a side effect of walking PodA's nested field.
+	s.MustImport(PodA{})
+
+	customized := false
+	s.MustImportAndCustomize(Container{}, func(schema *Schema) {
+		customized = true
+		schema.CollectionMethods = []string{"GET"}
+	})
+
+	if !customized {
+		t.Fatalf("expected customize callback to run")
+	}
+
+	container := s.Schema("container")
+	if len(container.CollectionMethods) != 1 || container.CollectionMethods[0] != "GET" {
+		t.Fatalf("expected customize to mutate the registered container schema, got %+v", container.CollectionMethods)
+	}
+}
+
+func TestAddMapperForTypeConcurrentWithTypeName(t *testing.T) {
+	s := EmptySchemas()
+	s.MustImport(Person{})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		s.TypeName("person", Person{})
+	}()
+
+	go func() {
+		defer wg.Done()
+		s.AddMapperForType(Person{})
+	}()
+
+	wg.Wait()
+}
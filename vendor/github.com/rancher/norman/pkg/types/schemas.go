@@ -22,6 +22,28 @@ type MapperFactory func() Mapper
 
 type FieldMapperFactory func(fieldName string, args ...string) Mapper
 
+// SchemaEventType identifies what happened to a Schema in a SchemaEvent.
+type SchemaEventType string
+
+const (
+	SchemaAdd    SchemaEventType = "add"
+	SchemaUpdate SchemaEventType = "update"
+	SchemaRemove SchemaEventType = "remove"
+)
+
+// SchemaEvent is delivered to Subscribe callbacks when a schema is added,
+// updated, or removed. Old is nil on SchemaAdd, New is nil on SchemaRemove.
+type SchemaEvent struct {
+	Type SchemaEventType
+	Old  *Schema
+	New  *Schema
+}
+
+// subscriberBacklog is the number of buffered events a subscriber channel
+// holds before the oldest pending event is dropped to make room. Dropping
+// keeps schema registration from blocking on a slow subscriber.
+const subscriberBacklog = 32
+
 type Schemas struct {
 	sync.Mutex
 	processingTypes   map[reflect.Type]*Schema
@@ -33,6 +55,10 @@ type Schemas struct {
 	DefaultMapper     MapperFactory
 	DefaultPostMapper MapperFactory
 	schemas           []*Schema
+	subscribers       []chan SchemaEvent
+
+	validators         map[string]ValidatorFunc
+	compiledValidators map[string]*compiledValidator
 }
 
 func EmptySchemas() *Schemas {
@@ -83,7 +109,21 @@ func (s *Schemas) RemoveSchema(schema Schema) *Schemas {
 }
 
 func (s *Schemas) doRemoveSchema(schema Schema) *Schemas {
+	existing, ok := s.schemasByID[schema.ID]
+	if !ok {
+		return s
+	}
+
 	delete(s.schemasByID, schema.ID)
+	for i, check := range s.schemas {
+		if check.ID == schema.ID {
+			s.schemas = append(s.schemas[:i], s.schemas[i+1:]...)
+			break
+		}
+	}
+	s.invalidateCompiledValidatorLocked(schema.ID)
+
+	s.publish(SchemaEvent{Type: SchemaRemove, Old: existing})
 	return s
 }
 
@@ -108,10 +148,20 @@ func (s *Schemas) doAddSchema(schema Schema) error {
 
 	existing, ok := s.schemasByID[schema.ID]
 	if ok {
-		*existing = schema
+		updated := schema
+		s.schemasByID[schema.ID] = &updated
+		for i, check := range s.schemas {
+			if check.ID == schema.ID {
+				s.schemas[i] = &updated
+				break
+			}
+		}
+		s.invalidateCompiledValidatorLocked(schema.ID)
+		s.publish(SchemaEvent{Type: SchemaUpdate, Old: existing, New: &updated})
 	} else {
 		s.schemasByID[schema.ID] = &schema
 		s.schemas = append(s.schemas, &schema)
+		s.publish(SchemaEvent{Type: SchemaAdd, New: &schema})
 	}
 
 	return nil
@@ -151,12 +201,26 @@ func (s *Schemas) AddMapper(schemaID string, mapper Mapper) *Schemas {
 	return s
 }
 
+// Schemas returns a snapshot of the registered schemas. The returned slice
+// is safe to range over even while AddSchema/RemoveSchema run concurrently.
 func (s *Schemas) Schemas() []*Schema {
-	return s.schemas
+	s.Lock()
+	defer s.Unlock()
+	result := make([]*Schema, len(s.schemas))
+	copy(result, s.schemas)
+	return result
 }
 
+// SchemasByID returns a snapshot of the schemasByID map. The returned map is
+// safe to read even while AddSchema/RemoveSchema run concurrently.
 func (s *Schemas) SchemasByID() map[string]*Schema {
-	return s.schemasByID
+	s.Lock()
+	defer s.Unlock()
+	result := make(map[string]*Schema, len(s.schemasByID))
+	for id, schema := range s.schemasByID {
+		result[id] = schema
+	}
+	return result
 }
 
 func (s *Schemas) mapper(schemaID string) []Mapper {
@@ -170,11 +234,10 @@ func (s *Schemas) Schema(name string) *Schema {
 func (s *Schemas) doSchema(name string, lock bool) *Schema {
 	if lock {
 		s.Lock()
+		defer s.Unlock()
 	}
+
 	schema, ok := s.schemasByID[name]
-	if lock {
-		s.Unlock()
-	}
 	if ok {
 		return schema
 	}
@@ -188,6 +251,43 @@ func (s *Schemas) doSchema(name string, lock bool) *Schema {
 	return nil
 }
 
+// Subscribe registers fn to be called with a SchemaEvent whenever a schema
+// is added, updated, or removed. Delivery happens on a dedicated goroutine
+// per subscriber with a buffered, drop-oldest queue, so a slow or stuck
+// subscriber cannot stall schema registration.
+func (s *Schemas) Subscribe(fn func(event SchemaEvent)) {
+	ch := make(chan SchemaEvent, subscriberBacklog)
+
+	s.Lock()
+	s.subscribers = append(s.subscribers, ch)
+	s.Unlock()
+
+	go func() {
+		for event := range ch {
+			fn(event)
+		}
+	}()
+}
+
+func (s *Schemas) publish(event SchemaEvent) {
+	for _, ch := range s.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Drop the oldest queued event to make room rather than
+			// block schema registration on a slow subscriber.
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+}
+
 type MultiErrors struct {
 	Errors []error
 }
@@ -0,0 +1,109 @@
+package types
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSchemasSnapshotIsolation(t *testing.T) {
+	s := EmptySchemas()
+	s.MustAddSchema(Schema{ID: "widget"})
+
+	snapshot := s.Schemas()
+	s.MustAddSchema(Schema{ID: "gadget"})
+
+	if len(snapshot) != 1 {
+		t.Fatalf("expected snapshot to keep its original length, got %d", len(snapshot))
+	}
+
+	byID := s.SchemasByID()
+	if len(byID) != 2 {
+		t.Fatalf("expected 2 schemas, got %d", len(byID))
+	}
+}
+
+func TestSchemasConcurrentAddAndIterate(t *testing.T) {
+	s := EmptySchemas()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			s.MustAddSchema(Schema{ID: "widget"})
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			for _, schema := range s.Schemas() {
+				_ = schema.ID
+			}
+		}
+	}()
+
+	wg.Wait()
+}
+
+func TestSchemasUpdateDoesNotMutateOldSnapshot(t *testing.T) {
+	s := EmptySchemas()
+	s.MustAddSchema(Schema{ID: "widget", CodeName: "v1"})
+
+	old := s.Schema("widget")
+	s.MustAddSchema(Schema{ID: "widget", CodeName: "v2"})
+
+	if old.CodeName != "v1" {
+		t.Fatalf("expected previously-fetched *Schema to remain v1, got %s", old.CodeName)
+	}
+	if s.Schema("widget").CodeName != "v2" {
+		t.Fatalf("expected current schema to be v2, got %s", s.Schema("widget").CodeName)
+	}
+}
+
+func TestSchemasSubscribeReceivesEvents(t *testing.T) {
+	s := EmptySchemas()
+
+	events := make(chan SchemaEvent, 4)
+	s.Subscribe(func(event SchemaEvent) {
+		events <- event
+	})
+
+	s.MustAddSchema(Schema{ID: "widget"})
+	s.MustAddSchema(Schema{ID: "widget"})
+	s.RemoveSchema(Schema{ID: "widget"})
+
+	var got []SchemaEvent
+	for len(got) < 3 {
+		select {
+		case event := <-events:
+			got = append(got, event)
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for events, got %d of 3", len(got))
+		}
+	}
+
+	if got[0].Type != SchemaAdd || got[1].Type != SchemaUpdate || got[2].Type != SchemaRemove {
+		t.Fatalf("unexpected event sequence: %+v", got)
+	}
+	if got[1].Old == got[1].New {
+		t.Fatalf("update event should carry distinct Old/New snapshots")
+	}
+}
+
+func TestSchemasSubscribeDoesNotBlockWhenFull(t *testing.T) {
+	s := EmptySchemas()
+
+	unblock := make(chan struct{})
+	s.Subscribe(func(event SchemaEvent) {
+		<-unblock
+	})
+
+	for i := 0; i < subscriberBacklog+10; i++ {
+		s.MustAddSchema(Schema{ID: "widget"})
+	}
+
+	close(unblock)
+}
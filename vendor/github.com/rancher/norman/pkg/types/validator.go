@@ -0,0 +1,390 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// scalarFieldTypes are the Rancher-style field types validated directly
+// against a data value. Any other field type is either array[...]/map[...]
+// (handled by recursing element-by-element) or the ID of another registered
+// schema (handled by recursing into that schema's own compiled validator).
+var scalarFieldTypes = map[string]bool{
+	"string":  true,
+	"int":     true,
+	"float":   true,
+	"boolean": true,
+	"enum":    true,
+	"date":    true,
+}
+
+// ValidatorFunc is a named, pluggable validation rule referenced from a
+// struct tag, e.g. `norman:"validate=dnsLabel"`. args are the rule's
+// own arguments, if any, written pipe-separated in parentheses after the
+// rule name (`validate=name(a|b)`); bounds and enum options are expressed
+// via the existing min/max/options tags instead.
+type ValidatorFunc func(value interface{}, args ...string) error
+
+// fieldValidator is the compiled form of a single ResourceField's tag-driven
+// validation rules, resolved once per schema so Validate does not re-parse
+// tags on every call.
+type fieldValidator struct {
+	name     string
+	field    Field
+	extra    []ValidatorFunc
+	extraArg [][]string
+}
+
+// compiledValidator is a schema's full set of fieldValidators, built after
+// assignMappers runs so that it reflects the final, mapper-adjusted fields.
+// It is cached per schema ID and invalidated by doAddSchema/doRemoveSchema
+// whenever that schema changes, so a hot-reloaded schema's rules take
+// effect on the next Validate call instead of being stuck on the first
+// compile.
+type compiledValidator struct {
+	schemaID string
+	fields   []fieldValidator
+}
+
+// invalidateCompiledValidatorLocked drops any cached validator for schemaID.
+// Callers must already hold s.Lock() (it is invoked from doAddSchema and
+// doRemoveSchema, which lock before mutating schemasByID/schemas).
+func (s *Schemas) invalidateCompiledValidatorLocked(schemaID string) {
+	delete(s.compiledValidators, schemaID)
+}
+
+// AddValidator registers a named custom validation rule that tags can
+// reference as `norman:"validate=<name>"` or `norman:"validate=<name>(arg1|arg2)"`.
+// It must be called before the schemas referencing it are added.
+func (s *Schemas) AddValidator(name string, fn ValidatorFunc) *Schemas {
+	s.Lock()
+	defer s.Unlock()
+	if s.validators == nil {
+		s.validators = map[string]ValidatorFunc{}
+	}
+	s.validators[name] = fn
+	return s
+}
+
+// Validate runs the compiled validation pipeline for schemaID against data,
+// returning an aggregated error with a JSON-pointer-style path
+// (e.g. /spec/containers/0/image) per failing field so API handlers can
+// return structured 422 responses instead of failing on the first error.
+// Fields typed array[<schema>]/map[<schema>], or typed as another
+// registered schema directly, recurse into that schema's own fields so
+// nested values are validated too, not just the top-level ones.
+func (s *Schemas) Validate(schemaID string, data map[string]interface{}) error {
+	schema := s.Schema(schemaID)
+	if schema == nil {
+		return fmt.Errorf("no such schema: %s", schemaID)
+	}
+
+	compiled, err := s.compileValidator(schema)
+	if err != nil {
+		return err
+	}
+
+	errs := &Errors{}
+	for _, fv := range compiled.fields {
+		errs.Add(s.validate(fv, "/"+fv.name, data[fv.name]))
+	}
+
+	return errs.Err()
+}
+
+// validate runs fv's required/bounds/options/pattern/custom rules against
+// value, recursing into array/map elements or a referenced schema's own
+// fields as needed.
+func (s *Schemas) validate(fv fieldValidator, path string, value interface{}) error {
+	if err := fv.validateRequired(path, value); err != nil {
+		return err
+	}
+	if value == nil {
+		return nil
+	}
+
+	if elemType, ok := arrayElemType(fv.field.Type); ok {
+		return s.validateArray(elemType, path, value)
+	}
+	if valueType, ok := mapValueType(fv.field.Type); ok {
+		return s.validateMap(valueType, path, value)
+	}
+	if !scalarFieldTypes[fv.field.Type] {
+		return s.validateNested(fv.field.Type, path, value)
+	}
+
+	return fv.validateScalar(path, value)
+}
+
+func (s *Schemas) validateArray(elemType, path string, value interface{}) error {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	errs := &Errors{}
+	for i, item := range items {
+		errs.Add(s.validateElem(elemType, fmt.Sprintf("%s/%d", path, i), item))
+	}
+	return errs.Err()
+}
+
+func (s *Schemas) validateMap(valueType, path string, value interface{}) error {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	errs := &Errors{}
+	for _, k := range keys {
+		errs.Add(s.validateElem(valueType, path+"/"+k, m[k]))
+	}
+	return errs.Err()
+}
+
+// validateElem validates a single array/map element against its declared
+// element type. A scalar element carries no constraints of its own, so it
+// always passes; an element typed as another registered schema is checked
+// against that schema's fields.
+func (s *Schemas) validateElem(elemType, path string, value interface{}) error {
+	if scalarFieldTypes[elemType] {
+		return fieldValidator{field: Field{Type: elemType}}.validateScalar(path, value)
+	}
+	return s.validateNested(elemType, path, value)
+}
+
+// validateNested validates value as an instance of the registered schema
+// named schemaID, recursing through that schema's own compiled validator so
+// its tag-driven rules (and its own nested fields) are enforced too. It is
+// a no-op if schemaID isn't a registered schema or value isn't an object.
+func (s *Schemas) validateNested(schemaID, path string, value interface{}) error {
+	nestedSchema := s.Schema(schemaID)
+	if nestedSchema == nil {
+		return nil
+	}
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	compiled, err := s.compileValidator(nestedSchema)
+	if err != nil {
+		return err
+	}
+
+	errs := &Errors{}
+	for _, fv := range compiled.fields {
+		errs.Add(s.validate(fv, path+"/"+fv.name, m[fv.name]))
+	}
+	return errs.Err()
+}
+
+func arrayElemType(fieldType string) (string, bool) {
+	if !strings.HasPrefix(fieldType, "array[") {
+		return "", false
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(fieldType, "array["), "]"), true
+}
+
+func mapValueType(fieldType string) (string, bool) {
+	if !strings.HasPrefix(fieldType, "map[") {
+		return "", false
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(fieldType, "map["), "]"), true
+}
+
+func (s *Schemas) compileValidator(schema *Schema) (*compiledValidator, error) {
+	s.Lock()
+	if s.compiledValidators == nil {
+		s.compiledValidators = map[string]*compiledValidator{}
+	}
+	if existing, ok := s.compiledValidators[schema.ID]; ok {
+		s.Unlock()
+		return existing, nil
+	}
+	s.Unlock()
+
+	compiled := &compiledValidator{schemaID: schema.ID}
+	for name, field := range schema.ResourceFields {
+		fv := fieldValidator{name: name, field: field}
+
+		rule, args, ok := parseValidateTag(field)
+		if ok {
+			fn, err := s.validatorFunc(rule)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", name, err)
+			}
+			fv.extra = append(fv.extra, fn)
+			fv.extraArg = append(fv.extraArg, args)
+		}
+
+		compiled.fields = append(compiled.fields, fv)
+	}
+
+	s.Lock()
+	s.compiledValidators[schema.ID] = compiled
+	s.Unlock()
+
+	return compiled, nil
+}
+
+func (s *Schemas) validatorFunc(name string) (ValidatorFunc, error) {
+	s.Lock()
+	defer s.Unlock()
+	fn, ok := s.validators[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown validator: %s", name)
+	}
+	return fn, nil
+}
+
+// parseValidateTag extracts a `validate=name` or `validate=name(a|b)` rule
+// carried in the ValidateRule field populated by the reflection importer
+// when it sees a `norman:"validate=..."` or `rio:"validate=..."` tag.
+func parseValidateTag(field Field) (name string, args []string, ok bool) {
+	if field.ValidateRule == "" {
+		return "", nil, false
+	}
+
+	rule := field.ValidateRule
+	if idx := strings.Index(rule, "("); idx >= 0 && strings.HasSuffix(rule, ")") {
+		name = rule[:idx]
+		args = strings.Split(rule[idx+1:len(rule)-1], "|")
+	} else {
+		name = rule
+	}
+
+	return name, args, true
+}
+
+// validateScalar applies fv's bounds/options/pattern/custom rules to a
+// non-nil scalar value. Required-ness and recursion into array/map/nested
+// values are handled by the (*Schemas).validate dispatcher.
+func (fv fieldValidator) validateScalar(path string, value interface{}) error {
+	if err := fv.validateBounds(path, value); err != nil {
+		return err
+	}
+
+	if err := fv.validateOptions(path, value); err != nil {
+		return err
+	}
+
+	if err := fv.validatePattern(path, value); err != nil {
+		return err
+	}
+
+	for i, fn := range fv.extra {
+		if err := fn(value, fv.extraArg[i]...); err != nil {
+			return &ValidationError{Path: path, Err: err}
+		}
+	}
+
+	return nil
+}
+
+func (fv fieldValidator) validateRequired(path string, value interface{}) error {
+	if !fv.field.Required {
+		return nil
+	}
+	if value == nil || value == "" {
+		return &ValidationError{Path: path, Err: fmt.Errorf("field is required")}
+	}
+	return nil
+}
+
+func (fv fieldValidator) validateBounds(path string, value interface{}) error {
+	if s, ok := value.(string); ok {
+		length := int64(len(s))
+		if fv.field.MinLength != nil && length < *fv.field.MinLength {
+			return &ValidationError{Path: path, Err: fmt.Errorf("length must be at least %d", *fv.field.MinLength)}
+		}
+		if fv.field.MaxLength != nil && length > *fv.field.MaxLength {
+			return &ValidationError{Path: path, Err: fmt.Errorf("length must be at most %d", *fv.field.MaxLength)}
+		}
+	}
+
+	n, ok := toInt64(value)
+	if !ok {
+		return nil
+	}
+	if fv.field.Min != nil && n < *fv.field.Min {
+		return &ValidationError{Path: path, Err: fmt.Errorf("must be at least %d", *fv.field.Min)}
+	}
+	if fv.field.Max != nil && n > *fv.field.Max {
+		return &ValidationError{Path: path, Err: fmt.Errorf("must be at most %d", *fv.field.Max)}
+	}
+
+	return nil
+}
+
+func (fv fieldValidator) validateOptions(path string, value interface{}) error {
+	if len(fv.field.Options) == 0 {
+		return nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	for _, option := range fv.field.Options {
+		if option == s {
+			return nil
+		}
+	}
+	return &ValidationError{Path: path, Err: fmt.Errorf("must be one of [%s]", strings.Join(fv.field.Options, ", "))}
+}
+
+func (fv fieldValidator) validatePattern(path string, value interface{}) error {
+	if fv.field.Pattern == "" {
+		return nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	matched, err := regexp.MatchString(fv.field.Pattern, s)
+	if err != nil {
+		return &ValidationError{Path: path, Err: err}
+	}
+	if !matched {
+		return &ValidationError{Path: path, Err: fmt.Errorf("does not match pattern %s", fv.field.Pattern)}
+	}
+	return nil
+}
+
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// ValidationError reports a single field failure at a JSON-pointer-style
+// path, e.g. /spec/containers/0/image.
+type ValidationError struct {
+	Path string
+	Err  error
+}
+
+func (v *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %v", v.Path, v.Err)
+}
+
+func (v *ValidationError) Unwrap() error {
+	return v.Err
+}
@@ -0,0 +1,118 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func newValidatorTestSchemas(t *testing.T) *Schemas {
+	t.Helper()
+	minLength := int64(1)
+	min := int64(0)
+	max := int64(65535)
+
+	s := EmptySchemas()
+	if err := s.AddSchema(Schema{
+		ID: "container",
+		ResourceFields: map[string]Field{
+			"image": {Type: "string", Required: true, MinLength: &minLength},
+			"port":  {Type: "int", Min: &min, Max: &max},
+		},
+	}); err != nil {
+		t.Fatalf("AddSchema(container): %v", err)
+	}
+	if err := s.AddSchema(Schema{
+		ID: "pod",
+		ResourceFields: map[string]Field{
+			"name":       {Type: "string", Required: true},
+			"containers": {Type: "array[container]"},
+		},
+	}); err != nil {
+		t.Fatalf("AddSchema(pod): %v", err)
+	}
+	return s
+}
+
+func TestValidateRequiredField(t *testing.T) {
+	s := newValidatorTestSchemas(t)
+
+	err := s.Validate("pod", map[string]interface{}{})
+	if err == nil || !strings.Contains(err.Error(), "/name") {
+		t.Fatalf("expected /name required error, got %v", err)
+	}
+}
+
+func TestValidateRecursesIntoNestedArraySchema(t *testing.T) {
+	s := newValidatorTestSchemas(t)
+
+	err := s.Validate("pod", map[string]interface{}{
+		"name": "web",
+		"containers": []interface{}{
+			map[string]interface{}{"port": 8080},
+		},
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "/containers/0/image") {
+		t.Fatalf("expected /containers/0/image required error, got %v", err)
+	}
+}
+
+func TestValidatePassesValidNestedData(t *testing.T) {
+	s := newValidatorTestSchemas(t)
+
+	err := s.Validate("pod", map[string]interface{}{
+		"name": "web",
+		"containers": []interface{}{
+			map[string]interface{}{"image": "nginx", "port": 80},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestValidateCustomValidator(t *testing.T) {
+	s := EmptySchemas()
+	s.AddValidator("dnsLabel", func(value interface{}, args ...string) error {
+		str, _ := value.(string)
+		if strings.Contains(str, "_") {
+			return fmt.Errorf("must not contain underscores")
+		}
+		return nil
+	})
+	s.MustAddSchema(Schema{
+		ID: "service",
+		ResourceFields: map[string]Field{
+			"name": {Type: "string", ValidateRule: "dnsLabel"},
+		},
+	})
+
+	if err := s.Validate("service", map[string]interface{}{"name": "bad_name"}); err == nil {
+		t.Fatalf("expected custom validator to reject underscore")
+	}
+	if err := s.Validate("service", map[string]interface{}{"name": "good-name"}); err != nil {
+		t.Fatalf("expected custom validator to accept good-name, got %v", err)
+	}
+}
+
+func TestCompiledValidatorInvalidatedOnSchemaUpdate(t *testing.T) {
+	s := EmptySchemas()
+	s.MustAddSchema(Schema{
+		ID:             "widget",
+		ResourceFields: map[string]Field{"name": {Type: "string"}},
+	})
+
+	if err := s.Validate("widget", map[string]interface{}{}); err != nil {
+		t.Fatalf("expected no error before update, got %v", err)
+	}
+
+	s.MustAddSchema(Schema{
+		ID:             "widget",
+		ResourceFields: map[string]Field{"name": {Type: "string", Required: true}},
+	})
+
+	if err := s.Validate("widget", map[string]interface{}{}); err == nil {
+		t.Fatalf("expected required error to take effect after schema update")
+	}
+}